@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/snsvistunov/summer-2022-practice-task"
+	"github.com/snsvistunov/summer-2022-practice-task/gtfs"
+)
+
+type Query struct {
+	DepartureStationID string
+	ArrivalStationID   string
+	Criteria           string
+}
+
+func (q *Query) readUserParamsFromTerminal() {
+
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Enter departure station ID: ")
+	q.DepartureStationID, _ = reader.ReadString('\n')
+	q.DepartureStationID = q.DepartureStationID[0 : len(q.DepartureStationID)-1]
+
+	fmt.Print("Enter arrival station ID: ")
+	q.ArrivalStationID, _ = reader.ReadString('\n')
+	q.ArrivalStationID = q.ArrivalStationID[0 : len(q.ArrivalStationID)-1]
+
+	fmt.Print("Enter sorting criteria: ")
+	q.Criteria, _ = reader.ReadString('\n')
+	q.Criteria = q.Criteria[0 : len(q.Criteria)-1]
+}
+
+func main() {
+	gtfsPath := flag.String("gtfs", "", "path to a GTFS feed zip, used instead of data.json")
+	flag.Parse()
+
+	//query data from user
+	query := new(Query)
+	query.readUserParamsFromTerminal()
+
+	var (
+		result trainfinder.Trains
+		err    error
+	)
+	if *gtfsPath != "" {
+		result, err = findTrainsFromGTFS(*gtfsPath, query)
+	} else {
+		result, err = trainfinder.FindTrains(query.DepartureStationID, query.ArrivalStationID, query.Criteria)
+	}
+
+	//handle error
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	//print result
+	trainfinder.PrintFindingResult(result)
+}
+
+// findTrainsFromGTFS loads the feed at gtfsPath and answers query against it,
+// so -gtfs can be used as a drop-in replacement for the data.json-backed
+// FindTrains.
+func findTrainsFromGTFS(gtfsPath string, query *Query) (trainfinder.Trains, error) {
+	trains, err := gtfs.LoadZip(gtfsPath)
+	if err != nil {
+		return nil, err
+	}
+	repo := trainfinder.NewMemoryRepository(trains)
+	return trainfinder.FindTrainsFrom(repo, query.DepartureStationID, query.ArrivalStationID, query.Criteria)
+}
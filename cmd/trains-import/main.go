@@ -0,0 +1,50 @@
+// Command trains-import ingests a data.json-shaped dataset into a SQL
+// backend, so trainsd or trains-cli can be pointed at a real database
+// instead of the bundled JSON file.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/snsvistunov/summer-2022-practice-task"
+	"github.com/snsvistunov/summer-2022-practice-task/repository"
+)
+
+func main() {
+	dataPath := flag.String("data", "data.json", "path to the dataset to import")
+	driver := flag.String("driver", "sqlite3", "database driver: sqlite3 or postgres")
+	dsn := flag.String("dsn", "trains.db", "data source name for the chosen driver")
+	flag.Parse()
+
+	dialect := repository.SQLite
+	if *driver == "postgres" {
+		dialect = repository.Postgres
+	}
+
+	data, err := trainfinder.LoadData(*dataPath)
+	if err != nil {
+		log.Fatalf("failed to load %s: %v", *dataPath, err)
+	}
+
+	db, err := sql.Open(*driver, *dsn)
+	if err != nil {
+		log.Fatalf("failed to open %s database %s: %v", *driver, *dsn, err)
+	}
+	defer db.Close()
+
+	repo := repository.NewSQLRepository(db, dialect)
+	if err := repo.CreateSchema(); err != nil {
+		log.Fatalf("failed to create schema: %v", err)
+	}
+
+	if err := repo.InsertAll(data); err != nil {
+		log.Fatalf("failed to import trains: %v", err)
+	}
+
+	log.Printf("imported %d trains from %s into %s (%s)", len(data), *dataPath, *dsn, *driver)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/snsvistunov/summer-2022-practice-task"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dataPath := flag.String("data", "data.json", "path to the train dataset")
+	flag.Parse()
+
+	repo, err := trainfinder.NewJSONFileRepository(*dataPath)
+	if err != nil {
+		log.Fatalf("failed to load %s: %v", *dataPath, err)
+	}
+	log.Printf("loaded trains from %s", *dataPath)
+
+	srv := &server{repo: repo}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/trains", srv.handleTrains)
+	mux.HandleFunc("/trains/search", srv.handleTrainsSearch)
+	mux.HandleFunc("/routes", srv.handleRoutes)
+	mux.HandleFunc("/healthz", srv.handleHealthz)
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, withLogging(mux)))
+}
+
+type server struct {
+	repo trainfinder.GraphRepository
+}
+
+func (s *server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+// handleTrains serves GET /trains?departure=&arrival=&criteria=&limit=&offset=.
+// criteria accepts a bare field ("price") or a composite, comma separated
+// expression ("price,departure-time"); a field prefixed with "-" sorts that
+// field descending (e.g. "price,-departure-time"). limit and offset override
+// the default paging.
+func (s *server) handleTrains(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	criteria := q.Get("criteria")
+	if criteria == "" {
+		criteria = "price"
+	}
+
+	spec, err := trainfinder.ParseSortSpec(criteria)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := applyPaging(&spec, q.Get("limit"), q.Get("offset")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	trains, err := trainfinder.FindTrainsWithSpec(s.repo, q.Get("departure"), q.Get("arrival"), spec)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, trains)
+}
+
+// handleRoutes serves GET /routes?departure=&arrival=&criteria=&maxTransfers=,
+// returning up to routesTopK itineraries ranked by criteria. maxTransfers is
+// clamped by FindRoutes, so a caller asking for an unreasonably large value
+// doesn't turn this into an unbounded amount of server-side work.
+func (s *server) handleRoutes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	criteria := q.Get("criteria")
+	if criteria == "" {
+		criteria = "price"
+	}
+
+	maxTransfers := 1
+	if raw := q.Get("maxTransfers"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeError(w, trainfinder.ErrCriteria)
+			return
+		}
+		maxTransfers = n
+	}
+
+	routes, err := trainfinder.FindRoutes(s.repo, q.Get("departure"), q.Get("arrival"), criteria, maxTransfers)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, routes)
+}
+
+func applyPaging(spec *trainfinder.SortSpec, limit, offset string) error {
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return trainfinder.ErrCriteria
+		}
+		spec.Max = n
+	}
+	if offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil {
+			return trainfinder.ErrCriteria
+		}
+		spec.Offset = n
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var fte *trainfinder.FindTrainsError
+	if errors.As(err, &fte) {
+		writeJSON(w, http.StatusBadRequest, fte)
+		return
+	}
+	writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// withLogging logs the method, path and duration of every request.
+func withLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		log.Printf("%s %s %s", r.Method, r.URL.Path, time.Since(start))
+	})
+}
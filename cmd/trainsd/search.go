@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/snsvistunov/summer-2022-practice-task"
+)
+
+// stationFilter is one entry of a searchRequest's "all" list: exactly one of
+// Dep/Arr is set, e.g. {"dep":1902} or {"arr":1929}.
+type stationFilter struct {
+	Dep *int `json:"dep,omitempty"`
+	Arr *int `json:"arr,omitempty"`
+}
+
+// searchRequest is the body accepted by POST /trains/search, e.g.
+//
+//	{"all":[{"dep":1902},{"arr":1929}], "sort":"price", "order":"asc", "max":10, "offset":0}
+//
+// Sort accepts the same "-field" per-field descending syntax as the
+// criteria query param (e.g. "price,-departure-time"); Order is a uniform
+// fallback applied only when Sort doesn't specify a direction for any
+// field, kept for backward compatibility with the single-field case.
+//
+// It marshals back out the same shape it was parsed from.
+type searchRequest struct {
+	All    []stationFilter `json:"all"`
+	Sort   string          `json:"sort"`
+	Order  string          `json:"order"`
+	Max    int             `json:"max"`
+	Offset int             `json:"offset"`
+}
+
+func (req searchRequest) stations() (departure, arrival string) {
+	for _, f := range req.All {
+		if f.Dep != nil {
+			departure = strconv.Itoa(*f.Dep)
+		}
+		if f.Arr != nil {
+			arrival = strconv.Itoa(*f.Arr)
+		}
+	}
+	return
+}
+
+func (req searchRequest) sortSpec() (trainfinder.SortSpec, error) {
+	spec, err := trainfinder.ParseSortSpec(req.Sort)
+	if err != nil {
+		return trainfinder.SortSpec{}, err
+	}
+
+	if !hasExplicitFieldOrder(req.Sort) {
+		desc := strings.EqualFold(req.Order, "desc")
+		for i := range spec.Fields {
+			spec.Fields[i].Desc = desc
+		}
+	}
+
+	spec.Max = req.Max
+	spec.Offset = req.Offset
+	return spec, nil
+}
+
+// hasExplicitFieldOrder reports whether sort gives at least one field its
+// own "-field" direction, in which case the request's top-level Order is
+// ignored rather than overriding each field uniformly.
+func hasExplicitFieldOrder(sort string) bool {
+	for _, name := range strings.Split(sort, ",") {
+		if strings.HasPrefix(strings.TrimSpace(name), "-") {
+			return true
+		}
+	}
+	return false
+}
+
+// handleTrainsSearch serves POST /trains/search with a structured JSON
+// query, as an alternative to the query-string form handleTrains serves.
+func (s *server) handleTrainsSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req searchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "malformed request body"})
+		return
+	}
+
+	spec, err := req.sortSpec()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	departure, arrival := req.stations()
+	trains, err := trainfinder.FindTrainsWithSpec(s.repo, departure, arrival, spec)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, trains)
+}
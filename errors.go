@@ -0,0 +1,58 @@
+package trainfinder
+
+import "encoding/json"
+
+// ErrorCode is a machine-readable identifier for a FindTrainsError, stable
+// across releases so API clients can switch on it instead of parsing
+// messages.
+type ErrorCode string
+
+const (
+	CodeUnsupportedCriteria ErrorCode = "unsupported_criteria"
+	CodeEmptyDeparture      ErrorCode = "empty_departure"
+	CodeEmptyArrival        ErrorCode = "empty_arrival"
+	CodeBadDeparture        ErrorCode = "bad_departure"
+	CodeBadArrival          ErrorCode = "bad_arrival"
+)
+
+// FindTrainsError is returned by FindTrains and the other query functions
+// for invalid input. Code identifies the failure kind, Field names the
+// offending query field (when there is one), and the message (via Error())
+// is unchanged from the plain sentinel errors this type replaces.
+type FindTrainsError struct {
+	Code    ErrorCode
+	Field   string
+	Message string
+}
+
+func (e *FindTrainsError) Error() string {
+	return e.Message
+}
+
+// Is lets errors.Is match any FindTrainsError with the same Code, not just
+// the exact sentinel instance.
+func (e *FindTrainsError) Is(target error) bool {
+	t, ok := target.(*FindTrainsError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// MarshalJSON renders the error as {"code":"...","message":"...","field":"..."},
+// so an HTTP layer can forward it to clients as-is.
+func (e *FindTrainsError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code    ErrorCode `json:"code"`
+		Message string    `json:"message"`
+		Field   string    `json:"field,omitempty"`
+	}{Code: e.Code, Message: e.Message, Field: e.Field})
+}
+
+var (
+	ErrCriteria        error = &FindTrainsError{Code: CodeUnsupportedCriteria, Message: "unsupported criteria"}
+	ErrEmptyDepStation error = &FindTrainsError{Code: CodeEmptyDeparture, Field: "departureStation", Message: "empty departure station"}
+	ErrEmptyArrStation error = &FindTrainsError{Code: CodeEmptyArrival, Field: "arrivalStation", Message: "empty arrival station"}
+	ErrBadDepStation   error = &FindTrainsError{Code: CodeBadDeparture, Field: "departureStation", Message: "bad departure station input"}
+	ErrBadArrStation   error = &FindTrainsError{Code: CodeBadArrival, Field: "arrivalStation", Message: "bad arrival station input"}
+)
@@ -0,0 +1,31 @@
+package trainfinder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTrainsErrorIs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, err := validateStations("", "1929")
+	assert.ErrorIs(err, ErrEmptyDepStation)
+	assert.NotErrorIs(err, ErrBadDepStation)
+
+	_, _, err = validateStations("serg", "1929")
+	assert.ErrorIs(err, ErrBadDepStation)
+
+	var fte *FindTrainsError
+	assert.ErrorAs(err, &fte)
+	assert.Equal(CodeBadDeparture, fte.Code)
+	assert.Equal("departureStation", fte.Field)
+}
+
+func TestFindTrainsErrorMarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := ErrBadDepStation.(*FindTrainsError).MarshalJSON()
+	assert.NoError(err)
+	assert.JSONEq(`{"code":"bad_departure","message":"bad departure station input","field":"departureStation"}`, string(b))
+}
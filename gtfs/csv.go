@@ -0,0 +1,57 @@
+package gtfs
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// row is one line of a GTFS CSV file, keyed by column name.
+type row map[string]string
+
+// readCSV reads name from r and returns one row per data line, keyed by its
+// header column names. A missing file returns (nil, nil): most GTFS files
+// besides stops.txt/trips.txt/stop_times.txt are optional.
+func readCSV(r *zip.Reader, name string) ([]row, error) {
+	f, err := r.Open(name)
+	if err != nil {
+		return nil, nil
+	}
+	defer f.Close()
+
+	return parseCSV(f, name)
+}
+
+func parseCSV(f io.Reader, name string) ([]row, error) {
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read header of %s: %w", name, err)
+	}
+
+	var rows []row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+
+		r := make(row, len(header))
+		for i, col := range header {
+			if i < len(record) {
+				r[col] = record[i]
+			}
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
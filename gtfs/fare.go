@@ -0,0 +1,36 @@
+package gtfs
+
+import (
+	"archive/zip"
+	"strconv"
+)
+
+// farePerRoute computes a price per route_id from the optional
+// fare_attributes.txt/fare_rules.txt pair. fare_rules.txt maps route_id to
+// fare_id, fare_attributes.txt maps fare_id to price; routes with no
+// matching fare rule default to a price of 0.
+func farePerRoute(r *zip.Reader) (map[string]float32, error) {
+	fareRules, err := readCSV(r, "fare_rules.txt")
+	if err != nil {
+		return nil, err
+	}
+	fareAttributes, err := readCSV(r, "fare_attributes.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	priceOfFare := make(map[string]float32, len(fareAttributes))
+	for _, fa := range fareAttributes {
+		price, err := strconv.ParseFloat(fa["price"], 32)
+		if err != nil {
+			continue
+		}
+		priceOfFare[fa["fare_id"]] = float32(price)
+	}
+
+	priceOfRoute := make(map[string]float32, len(fareRules))
+	for _, fr := range fareRules {
+		priceOfRoute[fr["route_id"]] = priceOfFare[fr["fare_id"]]
+	}
+	return priceOfRoute, nil
+}
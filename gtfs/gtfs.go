@@ -0,0 +1,142 @@
+// Package gtfs ingests a standard GTFS feed (stops.txt, trips.txt,
+// stop_times.txt, routes.txt, and optionally fare_rules.txt/
+// fare_attributes.txt) and produces trainfinder.Trains, so the rest of the
+// module can be pointed at any public transit agency's feed instead of a
+// hand-crafted data.json.
+package gtfs
+
+import (
+	"archive/zip"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/snsvistunov/summer-2022-practice-task"
+)
+
+// LoadZip reads a GTFS feed from a zip archive at path and returns one
+// Train per (trip_id, from_stop, to_stop) pair derived by pairing
+// consecutive stop_times entries.
+func LoadZip(path string) (trainfinder.Trains, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("open gtfs feed %s: %w", path, err)
+	}
+	defer r.Close()
+
+	return Load(&r.Reader)
+}
+
+// Load builds trains from an already-opened GTFS zip reader.
+func Load(r *zip.Reader) (trainfinder.Trains, error) {
+	stops, err := readCSV(r, "stops.txt")
+	if err != nil {
+		return nil, err
+	}
+	stopIDs := stationIDs(stops)
+
+	trips, err := readCSV(r, "trips.txt")
+	if err != nil {
+		return nil, err
+	}
+	routeOfTrip := make(map[string]string, len(trips))
+	for _, t := range trips {
+		routeOfTrip[t["trip_id"]] = t["route_id"]
+	}
+
+	priceOfRoute, err := farePerRoute(r)
+	if err != nil {
+		return nil, err
+	}
+
+	stopTimes, err := readCSV(r, "stop_times.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTrains(stopTimes, stopIDs, routeOfTrip, priceOfRoute)
+}
+
+// stationIDs maps each GTFS stop_id to the integer station ID Train uses.
+// A numeric stop_id is used as-is; non-numeric ones get a synthetic ID,
+// assigned in sorted order so the mapping is stable across runs.
+func stationIDs(stops []row) map[string]int {
+	ids := make(map[string]int, len(stops))
+
+	var unresolved []string
+	maxNumeric := 0
+	for _, s := range stops {
+		id := s["stop_id"]
+		if n, err := strconv.Atoi(id); err == nil {
+			ids[id] = n
+			if n > maxNumeric {
+				maxNumeric = n
+			}
+			continue
+		}
+		unresolved = append(unresolved, id)
+	}
+
+	sort.Strings(unresolved)
+	next := maxNumeric + 1
+	for _, id := range unresolved {
+		ids[id] = next
+		next++
+	}
+	return ids
+}
+
+// buildTrains groups stop_times by trip, orders each trip's stops by
+// stop_sequence, and turns every pair of consecutive stops into a Train.
+func buildTrains(stopTimes []row, stopIDs map[string]int, routeOfTrip map[string]string, priceOfRoute map[string]float32) (trainfinder.Trains, error) {
+	byTrip := make(map[string][]row)
+	for _, st := range stopTimes {
+		tripID := st["trip_id"]
+		byTrip[tripID] = append(byTrip[tripID], st)
+	}
+
+	tripIDs := make([]string, 0, len(byTrip))
+	for tripID := range byTrip {
+		tripIDs = append(tripIDs, tripID)
+	}
+	sort.Strings(tripIDs)
+
+	trains := make(trainfinder.Trains, 0)
+	trainID := 1
+
+	for _, tripID := range tripIDs {
+		stops := byTrip[tripID]
+		sort.Slice(stops, func(i, j int) bool {
+			seqI, _ := strconv.Atoi(stops[i]["stop_sequence"])
+			seqJ, _ := strconv.Atoi(stops[j]["stop_sequence"])
+			return seqI < seqJ
+		})
+
+		price := priceOfRoute[routeOfTrip[tripID]]
+
+		for i := 0; i+1 < len(stops); i++ {
+			from, to := stops[i], stops[i+1]
+
+			departureTime, err := trainfinder.ParseClockTime(from["departure_time"])
+			if err != nil {
+				return nil, fmt.Errorf("trip %s stop %d: parse departure_time: %w", tripID, i, err)
+			}
+			arrivalTime, err := trainfinder.ParseClockTime(to["arrival_time"])
+			if err != nil {
+				return nil, fmt.Errorf("trip %s stop %d: parse arrival_time: %w", tripID, i+1, err)
+			}
+
+			trains = append(trains, trainfinder.Train{
+				TrainID:            trainID,
+				DepartureStationID: stopIDs[from["stop_id"]],
+				ArrivalStationID:   stopIDs[to["stop_id"]],
+				Price:              price,
+				DepartureTime:      departureTime,
+				ArrivalTime:        arrivalTime,
+			})
+			trainID++
+		}
+	}
+
+	return trains, nil
+}
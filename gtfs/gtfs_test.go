@@ -0,0 +1,75 @@
+package gtfs
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, w *zip.Writer, name, content string) {
+	t.Helper()
+	f, err := w.Create(name)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte(content))
+	assert.NoError(t, err)
+}
+
+func openFixture(t *testing.T, buf *bytes.Buffer) *zip.Reader {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	return r
+}
+
+func TestLoadBuildsTrainsFromFeed(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	writeFile(t, w, "stops.txt", "stop_id,stop_name\n1,Start\n2,Middle\n3,End\n")
+	writeFile(t, w, "trips.txt", "trip_id,route_id\nt1,r1\n")
+	writeFile(t, w, "routes.txt", "route_id,route_short_name\nr1,Line 1\n")
+	writeFile(t, w, "stop_times.txt",
+		"trip_id,stop_id,stop_sequence,arrival_time,departure_time\n"+
+			"t1,1,1,09:00:00,09:00:00\n"+
+			"t1,2,2,09:30:00,09:35:00\n"+
+			"t1,3,3,10:00:00,10:00:00\n")
+	writeFile(t, w, "fare_rules.txt", "route_id,fare_id\nr1,f1\n")
+	writeFile(t, w, "fare_attributes.txt", "fare_id,price\nf1,12.5\n")
+	assert.NoError(w.Close())
+
+	trains, err := Load(openFixture(t, &buf))
+	assert.NoError(err)
+	assert.Len(trains, 2)
+
+	assert.Equal(1, trains[0].DepartureStationID)
+	assert.Equal(2, trains[0].ArrivalStationID)
+	assert.Equal(float32(12.5), trains[0].Price)
+	assert.Equal("09:00:00", trains[0].DepartureTime.Format("15:04:05"))
+	assert.Equal("09:30:00", trains[0].ArrivalTime.Format("15:04:05"))
+
+	assert.Equal(2, trains[1].DepartureStationID)
+	assert.Equal(3, trains[1].ArrivalStationID)
+	assert.Equal(float32(12.5), trains[1].Price)
+}
+
+func TestLoadWithoutFareRulesDefaultsToZeroPrice(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	writeFile(t, w, "stops.txt", "stop_id,stop_name\n1,Start\n2,End\n")
+	writeFile(t, w, "trips.txt", "trip_id,route_id\nt1,r1\n")
+	writeFile(t, w, "stop_times.txt",
+		"trip_id,stop_id,stop_sequence,arrival_time,departure_time\n"+
+			"t1,1,1,08:00:00,08:00:00\n"+
+			"t1,2,2,08:15:00,08:15:00\n")
+	assert.NoError(w.Close())
+
+	trains, err := Load(openFixture(t, &buf))
+	assert.NoError(err)
+	assert.Len(trains, 1)
+	assert.Equal(float32(0), trains[0].Price)
+}
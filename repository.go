@@ -0,0 +1,91 @@
+package trainfinder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+)
+
+// TrainRepository looks up trains for a route. Implementations decide where
+// the data comes from: a JSON file, an in-memory fixture, a SQL database, or
+// a remote HTTP endpoint (see the repository subpackage for the latter two).
+type TrainRepository interface {
+	FindByRoute(departureStationID, arrivalStationID int) (Trains, error)
+}
+
+// LoadData reads and parses a train dataset from path.
+func LoadData(path string) (Trains, error) {
+	jsonFile, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer jsonFile.Close()
+
+	byteValue, err := ioutil.ReadAll(jsonFile)
+	if err != nil {
+		return nil, err
+	}
+
+	d := make(Trains, 0)
+	if err := json.Unmarshal(byteValue, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// JSONFileRepository loads a dataset from a JSON file once, at construction
+// time, and serves every subsequent lookup from memory.
+type JSONFileRepository struct {
+	data Trains
+}
+
+// NewJSONFileRepository loads path and returns a repository backed by it.
+func NewJSONFileRepository(path string) (*JSONFileRepository, error) {
+	data, err := LoadData(path)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONFileRepository{data: data}, nil
+}
+
+func (r *JSONFileRepository) FindByRoute(departureStationID, arrivalStationID int) (Trains, error) {
+	return filterByRoute(r.data, departureStationID, arrivalStationID), nil
+}
+
+// AllTrains returns the full dataset, for callers that need to build a
+// route graph rather than look up a single departure/arrival pair.
+func (r *JSONFileRepository) AllTrains() (Trains, error) {
+	return r.data, nil
+}
+
+// MemoryRepository serves lookups from a fixed in-memory dataset, with no
+// I/O at all. It's mainly useful in tests that shouldn't touch the
+// filesystem.
+type MemoryRepository struct {
+	data Trains
+}
+
+// NewMemoryRepository returns a repository backed by data as-is.
+func NewMemoryRepository(data Trains) *MemoryRepository {
+	return &MemoryRepository{data: data}
+}
+
+func (r *MemoryRepository) FindByRoute(departureStationID, arrivalStationID int) (Trains, error) {
+	return filterByRoute(r.data, departureStationID, arrivalStationID), nil
+}
+
+// AllTrains returns the full dataset, for callers that need to build a
+// route graph rather than look up a single departure/arrival pair.
+func (r *MemoryRepository) AllTrains() (Trains, error) {
+	return r.data, nil
+}
+
+func filterByRoute(data Trains, departureStationID, arrivalStationID int) Trains {
+	trains := make(Trains, 0)
+	for _, v := range data {
+		if v.DepartureStationID == departureStationID && v.ArrivalStationID == arrivalStationID {
+			trains = append(trains, v)
+		}
+	}
+	return trains
+}
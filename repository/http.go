@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/snsvistunov/summer-2022-practice-task"
+)
+
+// HTTPRepository fetches trains for a route from a remote endpoint that
+// returns the same JSON shape as data.json, e.g. an upstream schedule API.
+type HTTPRepository struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPRepository returns a repository that queries baseURL with
+// ?departureStationId=&arrivalStationId= for each lookup.
+func NewHTTPRepository(baseURL string) *HTTPRepository {
+	return &HTTPRepository{baseURL: baseURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (r *HTTPRepository) FindByRoute(departureStationID, arrivalStationID int) (trainfinder.Trains, error) {
+	u, err := url.Parse(r.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("departureStationId", fmt.Sprint(departureStationID))
+	q.Set("arrivalStationId", fmt.Sprint(arrivalStationID))
+	u.RawQuery = q.Encode()
+
+	resp, err := r.client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch trains from %s: unexpected status %s", u, resp.Status)
+	}
+
+	trains := make(trainfinder.Trains, 0)
+	if err := json.NewDecoder(resp.Body).Decode(&trains); err != nil {
+		return nil, fmt.Errorf("decode trains from %s: %w", u, err)
+	}
+	return trains, nil
+}
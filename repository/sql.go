@@ -0,0 +1,137 @@
+// Package repository provides TrainRepository implementations beyond the
+// JSON-file and in-memory ones that live in the trainfinder package itself:
+// a SQL-backed repository and an HTTP fetcher.
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/snsvistunov/summer-2022-practice-task"
+)
+
+const timeLayout = "15:04:05"
+
+// SQLRepository looks up trains from a SQL database reachable through db.
+// It works with any driver registered with database/sql; the caller opens
+// db with whichever driver it wants (sqlite3, postgres, ...) and passes the
+// matching dialect so SQLRepository can generate the right placeholders.
+type SQLRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// Dialect picks the bind-variable style a driver expects.
+type Dialect int
+
+const (
+	SQLite Dialect = iota
+	Postgres
+)
+
+func NewSQLRepository(db *sql.DB, dialect Dialect) *SQLRepository {
+	return &SQLRepository{db: db, dialect: dialect}
+}
+
+func (d Dialect) placeholder(n int) string {
+	if d == Postgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// CreateSchema creates the trains table and the index the route lookup
+// relies on, if they don't already exist.
+func (r *SQLRepository) CreateSchema() error {
+	_, err := r.db.Exec(`
+CREATE TABLE IF NOT EXISTS trains (
+	train_id INTEGER PRIMARY KEY,
+	departure_station_id INTEGER NOT NULL,
+	arrival_station_id INTEGER NOT NULL,
+	price REAL NOT NULL,
+	arrival_time TEXT NOT NULL,
+	departure_time TEXT NOT NULL
+)`)
+	if err != nil {
+		return fmt.Errorf("create trains table: %w", err)
+	}
+
+	_, err = r.db.Exec(`CREATE INDEX IF NOT EXISTS idx_trains_route ON trains (departure_station_id, arrival_station_id)`)
+	if err != nil {
+		return fmt.Errorf("create route index: %w", err)
+	}
+	return nil
+}
+
+// InsertAll upserts every train in data into the trains table, used by the
+// trains-import command to seed a SQL backend from data.json.
+func (r *SQLRepository) InsertAll(data trainfinder.Trains) error {
+	for _, t := range data {
+		query := fmt.Sprintf(
+			`INSERT INTO trains (train_id, departure_station_id, arrival_station_id, price, arrival_time, departure_time)
+			 VALUES (%s, %s, %s, %s, %s, %s)
+			 ON CONFLICT (train_id) DO UPDATE SET
+				departure_station_id = excluded.departure_station_id,
+				arrival_station_id = excluded.arrival_station_id,
+				price = excluded.price,
+				arrival_time = excluded.arrival_time,
+				departure_time = excluded.departure_time`,
+			r.dialect.placeholder(1), r.dialect.placeholder(2), r.dialect.placeholder(3),
+			r.dialect.placeholder(4), r.dialect.placeholder(5), r.dialect.placeholder(6),
+		)
+		_, err := r.db.Exec(query,
+			t.TrainID, t.DepartureStationID, t.ArrivalStationID, t.Price,
+			t.ArrivalTime.Format(timeLayout), t.DepartureTime.Format(timeLayout),
+		)
+		if err != nil {
+			return fmt.Errorf("insert train %d: %w", t.TrainID, err)
+		}
+	}
+	return nil
+}
+
+func (r *SQLRepository) FindByRoute(departureStationID, arrivalStationID int) (trainfinder.Trains, error) {
+	query := fmt.Sprintf(
+		`SELECT train_id, departure_station_id, arrival_station_id, price, arrival_time, departure_time
+		 FROM trains WHERE departure_station_id = %s AND arrival_station_id = %s`,
+		r.dialect.placeholder(1), r.dialect.placeholder(2),
+	)
+	rows, err := r.db.Query(query, departureStationID, arrivalStationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTrains(rows)
+}
+
+// AllTrains returns the full trains table, for callers that need to build a
+// route graph rather than look up a single departure/arrival pair.
+func (r *SQLRepository) AllTrains() (trainfinder.Trains, error) {
+	rows, err := r.db.Query(`SELECT train_id, departure_station_id, arrival_station_id, price, arrival_time, departure_time FROM trains`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTrains(rows)
+}
+
+func scanTrains(rows *sql.Rows) (trainfinder.Trains, error) {
+	trains := make(trainfinder.Trains, 0)
+	for rows.Next() {
+		var t trainfinder.Train
+		var arr, dep string
+		if err := rows.Scan(&t.TrainID, &t.DepartureStationID, &t.ArrivalStationID, &t.Price, &arr, &dep); err != nil {
+			return nil, err
+		}
+		var err error
+		if t.ArrivalTime, err = time.Parse(timeLayout, arr); err != nil {
+			return nil, err
+		}
+		if t.DepartureTime, err = time.Parse(timeLayout, dep); err != nil {
+			return nil, err
+		}
+		trains = append(trains, t)
+	}
+	return trains, rows.Err()
+}
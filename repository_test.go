@@ -0,0 +1,44 @@
+package trainfinder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepositoryFindByRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 100, ArrivalTime: time.Date(0, time.January, 1, 10, 0, 0, 0, time.UTC), DepartureTime: time.Date(0, time.January, 1, 9, 0, 0, 0, time.UTC)},
+		{TrainID: 2, DepartureStationID: 1902, ArrivalStationID: 2000, Price: 200, ArrivalTime: time.Date(0, time.January, 1, 11, 0, 0, 0, time.UTC), DepartureTime: time.Date(0, time.January, 1, 9, 30, 0, 0, time.UTC)},
+	}
+
+	repo := NewMemoryRepository(data)
+
+	got, err := repo.FindByRoute(1902, 1929)
+	assert.NoError(err)
+	assert.Equal(Trains{data[0]}, got)
+
+	got, err = repo.FindByRoute(1902, 9999)
+	assert.NoError(err)
+	assert.Empty(got)
+}
+
+func TestFindTrainsFromUsesRepository(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 100, ArrivalTime: time.Date(0, time.January, 1, 10, 0, 0, 0, time.UTC), DepartureTime: time.Date(0, time.January, 1, 9, 0, 0, 0, time.UTC)},
+		{TrainID: 2, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 50, ArrivalTime: time.Date(0, time.January, 1, 12, 0, 0, 0, time.UTC), DepartureTime: time.Date(0, time.January, 1, 9, 30, 0, 0, time.UTC)},
+	}
+	repo := NewMemoryRepository(data)
+
+	got, err := FindTrainsFrom(repo, "1902", "1929", "price")
+	assert.NoError(err)
+	assert.Equal(Trains{data[1], data[0]}, got)
+
+	_, err = FindTrainsFrom(repo, "", "1929", "price")
+	assert.ErrorIs(err, ErrEmptyDepStation)
+}
@@ -0,0 +1,274 @@
+package trainfinder
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultMinTransferMinutes is how long a traveler needs at a station
+	// to change trains, used by FindRoutes.
+	DefaultMinTransferMinutes = 10
+	routesTopK                = numOfReturnTrains
+	// maxJourneyHours caps how long a multi-leg journey is allowed to take
+	// in total. Connection waits are normalized modulo 24h (the dataset
+	// only has times of day, not dates), so without a cap a pathological
+	// sequence of almost-a-full-day waits could make the search run
+	// indefinitely.
+	maxJourneyHours = 72
+	// maxAllowedTransfers is a hard ceiling on maxTransfers, independent of
+	// whatever a caller asks for. search enumerates simple paths rather than
+	// merging states by (station, legsUsed), so its cost grows with the
+	// graph's branching factor raised to maxTransfers; callers taking
+	// maxTransfers from untrusted input (e.g. an HTTP query param) must not
+	// be able to drive that exponent arbitrarily high.
+	maxAllowedTransfers = 4
+)
+
+// GraphRepository exposes the full dataset backing a TrainRepository, so
+// FindRoutes can build a station graph instead of looking up a single
+// departure/arrival pair.
+type GraphRepository interface {
+	TrainRepository
+	AllTrains() (Trains, error)
+}
+
+// Leg is one train ride within a Route, together with how long the
+// traveler waits at the departure station before boarding it (zero for the
+// first leg of a route).
+type Leg struct {
+	Train   Train
+	WaitFor time.Duration
+}
+
+// Route is an itinerary connecting a departure to an arrival station,
+// possibly via one or more intermediate stations.
+type Route struct {
+	Legs []Leg
+}
+
+// Transfers is the number of train changes the route involves.
+func (r Route) Transfers() int {
+	return len(r.Legs) - 1
+}
+
+// TotalPrice is the sum of every leg's price.
+func (r Route) TotalPrice() float32 {
+	var total float32
+	for _, leg := range r.Legs {
+		total += leg.Train.Price
+	}
+	return total
+}
+
+// TotalDuration is the wall-clock time from boarding the first leg to
+// arriving on the last one, including connection waits.
+func (r Route) TotalDuration() time.Duration {
+	var total time.Duration
+	for _, leg := range r.Legs {
+		total += leg.WaitFor + rideDuration(leg.Train)
+	}
+	return total
+}
+
+// rideDuration returns how long a train is underway, normalized modulo 24h
+// so overnight trains (arrival time-of-day earlier than departure) are
+// handled the same way CustomTime already is elsewhere in this package.
+func rideDuration(t Train) time.Duration {
+	return normalizeWait(t.ArrivalTime.Sub(t.DepartureTime))
+}
+
+// normalizeWait folds a negative duration into the next day, since the
+// dataset only carries times of day rather than full timestamps.
+func normalizeWait(d time.Duration) time.Duration {
+	if d < 0 {
+		d += 24 * time.Hour
+	}
+	return d
+}
+
+// FindRoutes computes up to routesTopK itineraries from departureStation to
+// arrivalStation using at most maxTransfers train changes (clamped to
+// maxAllowedTransfers), ranked by criteria ("price" minimizes total price;
+// "arrival-time" and "departure-time" both minimize total elapsed time, i.e.
+// earliest arrival). It returns (nil, nil) when no itinerary exists.
+func FindRoutes(repo GraphRepository, departureStation, arrivalStation, criteria string, maxTransfers int) ([]Route, error) {
+	return FindRoutesWithMinTransfer(repo, departureStation, arrivalStation, criteria, maxTransfers, DefaultMinTransferMinutes)
+}
+
+// FindRoutesWithMinTransfer is FindRoutes with a configurable minimum
+// connection time, in minutes, between legs.
+func FindRoutesWithMinTransfer(repo GraphRepository, departureStation, arrivalStation, criteria string, maxTransfers, minTransferMinutes int) ([]Route, error) {
+	departureStationID, arrivalStationID, err := validateStations(departureStation, arrivalStation)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := criteriaOfSort[criteria]; !ok {
+		return nil, ErrCriteria
+	}
+	if maxTransfers < 0 {
+		maxTransfers = 0
+	}
+	if maxTransfers > maxAllowedTransfers {
+		maxTransfers = maxAllowedTransfers
+	}
+
+	data, err := repo.AllTrains()
+	if err != nil {
+		return nil, err
+	}
+
+	graph := buildGraph(data)
+	minTransfer := time.Duration(minTransferMinutes) * time.Minute
+
+	routes := search(graph, departureStationID, arrivalStationID, criteria, maxTransfers, minTransfer)
+	if len(routes) < minLen {
+		return nil, nil
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routeCost(routes[i], criteria) < routeCost(routes[j], criteria)
+	})
+	if len(routes) > routesTopK {
+		routes = routes[:routesTopK]
+	}
+	return routes, nil
+}
+
+func routeCost(r Route, criteria string) float64 {
+	if criteria == priceCriteria {
+		return float64(r.TotalPrice())
+	}
+	return r.TotalDuration().Minutes()
+}
+
+// buildGraph groups trains by departure station, so neighbors of a station
+// can be looked up in constant time while searching.
+func buildGraph(data Trains) map[int]Trains {
+	graph := make(map[int]Trains)
+	for _, t := range data {
+		graph[t.DepartureStationID] = append(graph[t.DepartureStationID], t)
+	}
+	return graph
+}
+
+// pathState is one partial route explored by search: the station it has
+// reached, how many legs it used to get there, and its accumulated cost.
+type pathState struct {
+	station  int
+	legsUsed int
+	cost     float64
+	duration time.Duration
+	legs     []Leg
+	visited  map[int]bool
+}
+
+type stateQueue []*pathState
+
+func (q stateQueue) Len() int            { return len(q) }
+func (q stateQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q stateQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *stateQueue) Push(x interface{}) { *q = append(*q, x.(*pathState)) }
+func (q *stateQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
+
+// search explores simple (no repeated station) paths from departureStationID
+// in cost order using a priority queue, so it behaves like Dijkstra in the
+// order it visits states but, because each pathState carries its own visited
+// set rather than merging on (station, legsUsed), it is enumerating paths
+// rather than running a true label-setting Dijkstra: its cost is exponential
+// in the graph's branching factor, not polynomial. maxTransfers (clamped to
+// maxAllowedTransfers by callers) bounds that exponent and guarantees
+// termination despite the modulo-24h wait normalization. Reaching
+// arrivalStationID with a given number of legs is recorded the first time
+// it's popped, which is still optimal for that leg count since the queue is
+// cost ordered; callers pick the best routesTopK across leg counts.
+func search(graph map[int]Trains, departureStationID, arrivalStationID int, criteria string, maxTransfers int, minTransfer time.Duration) []Route {
+	maxLegs := maxTransfers + 1
+	maxDuration := time.Duration(maxJourneyHours) * time.Hour
+
+	queue := &stateQueue{{
+		station: departureStationID,
+		visited: map[int]bool{departureStationID: true},
+	}}
+	heap.Init(queue)
+
+	foundForLegs := make(map[int]bool)
+	var routes []Route
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(*pathState)
+
+		if current.station == arrivalStationID && current.legsUsed >= 1 {
+			if !foundForLegs[current.legsUsed] {
+				foundForLegs[current.legsUsed] = true
+				routes = append(routes, Route{Legs: current.legs})
+			}
+			if len(foundForLegs) >= maxLegs {
+				break
+			}
+		}
+
+		if current.legsUsed >= maxLegs {
+			continue
+		}
+
+		var lastArrival time.Time
+		if current.legsUsed > 0 {
+			lastArrival = current.legs[current.legsUsed-1].Train.ArrivalTime
+		}
+
+		for _, edge := range graph[current.station] {
+			if current.visited[edge.ArrivalStationID] {
+				continue
+			}
+
+			var wait time.Duration
+			if current.legsUsed > 0 {
+				wait = normalizeWait(edge.DepartureTime.Sub(lastArrival))
+				if wait < minTransfer {
+					continue
+				}
+			}
+
+			duration := current.duration + wait + rideDuration(edge)
+			if duration > maxDuration {
+				continue
+			}
+
+			cost := current.cost
+			if criteria == priceCriteria {
+				cost += float64(edge.Price)
+			} else {
+				cost += (wait + rideDuration(edge)).Minutes()
+			}
+
+			visited := make(map[int]bool, len(current.visited)+1)
+			for s := range current.visited {
+				visited[s] = true
+			}
+			visited[edge.ArrivalStationID] = true
+
+			legs := make([]Leg, current.legsUsed, current.legsUsed+1)
+			copy(legs, current.legs)
+			legs = append(legs, Leg{Train: edge, WaitFor: wait})
+
+			heap.Push(queue, &pathState{
+				station:  edge.ArrivalStationID,
+				legsUsed: current.legsUsed + 1,
+				cost:     cost,
+				duration: duration,
+				legs:     legs,
+				visited:  visited,
+			})
+		}
+	}
+
+	return routes
+}
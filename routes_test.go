@@ -0,0 +1,93 @@
+package trainfinder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(0, time.January, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestFindRoutesZeroTransfers(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 100, ArrivalStationID: 200, Price: 50, DepartureTime: at(9, 0), ArrivalTime: at(11, 0)},
+	}
+	repo := NewMemoryRepository(data)
+
+	routes, err := FindRoutes(repo, "100", "200", "price", 1)
+	assert.NoError(err)
+	if assert.Len(routes, 1) {
+		assert.Equal(0, routes[0].Transfers())
+		assert.Equal(float32(50), routes[0].TotalPrice())
+	}
+}
+
+func TestFindRoutesOneTransfer(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 100, ArrivalStationID: 150, Price: 30, DepartureTime: at(9, 0), ArrivalTime: at(10, 0)},
+		{TrainID: 2, DepartureStationID: 150, ArrivalStationID: 200, Price: 40, DepartureTime: at(10, 20), ArrivalTime: at(12, 0)},
+		// too tight a connection: arrives 10:00, leaves 10:05 (< 10 min minimum transfer)
+		{TrainID: 3, DepartureStationID: 150, ArrivalStationID: 200, Price: 10, DepartureTime: at(10, 5), ArrivalTime: at(11, 0)},
+	}
+	repo := NewMemoryRepository(data)
+
+	routes, err := FindRoutes(repo, "100", "200", "price", 1)
+	assert.NoError(err)
+	if assert.Len(routes, 1) {
+		assert.Equal(1, routes[0].Transfers())
+		assert.Equal(2, routes[0].Legs[1].Train.TrainID)
+		assert.Equal(20*time.Minute, routes[0].Legs[1].WaitFor)
+	}
+}
+
+func TestFindRoutesUnreachable(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 100, ArrivalStationID: 150, Price: 30, DepartureTime: at(9, 0), ArrivalTime: at(10, 0)},
+	}
+	repo := NewMemoryRepository(data)
+
+	routes, err := FindRoutes(repo, "100", "999", "price", 2)
+	assert.NoError(err)
+	assert.Nil(routes)
+}
+
+func TestFindRoutesRespectsMaxTransfers(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 100, ArrivalStationID: 150, Price: 30, DepartureTime: at(9, 0), ArrivalTime: at(10, 0)},
+		{TrainID: 2, DepartureStationID: 150, ArrivalStationID: 200, Price: 40, DepartureTime: at(10, 20), ArrivalTime: at(12, 0)},
+	}
+	repo := NewMemoryRepository(data)
+
+	routes, err := FindRoutes(repo, "100", "200", "price", 0)
+	assert.NoError(err)
+	assert.Nil(routes)
+}
+
+func TestFindRoutesClampsMaxTransfers(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 100, ArrivalStationID: 150, Price: 30, DepartureTime: at(9, 0), ArrivalTime: at(10, 0)},
+		{TrainID: 2, DepartureStationID: 150, ArrivalStationID: 200, Price: 40, DepartureTime: at(10, 20), ArrivalTime: at(12, 0)},
+	}
+	repo := NewMemoryRepository(data)
+
+	// maxAllowedTransfers is well below the huge value requested; the call
+	// must still return (not hang) and find the one available route.
+	routes, err := FindRoutes(repo, "100", "200", "price", 1000000)
+	assert.NoError(err)
+	if assert.Len(routes, 1) {
+		assert.Equal(1, routes[0].Transfers())
+	}
+}
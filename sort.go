@@ -0,0 +1,101 @@
+package trainfinder
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortField is one key in a composite ordering: sort by Name, descending if
+// Desc is set.
+type SortField struct {
+	Name string
+	Desc bool
+}
+
+// SortSpec describes how FindTrainsWithSpec should order and page a result
+// set: Fields are applied in order (later fields break ties left by earlier
+// ones), Max caps the number of trains returned (numOfReturnTrains if <= 0,
+// mirroring FindTrains' historical default), and Offset skips that many
+// trains before applying Max.
+type SortSpec struct {
+	Fields []SortField
+	Max    int
+	Offset int
+}
+
+// ParseSortSpec parses a bare or comma separated criteria expression, e.g.
+// "price" or "price,departure-time", into a SortSpec with the default
+// Max/Offset. A field name prefixed with "-", e.g. "price,-departure-time",
+// sorts that field descending; every other field sorts ascending. It's the
+// same parsing FindTrains has always done; composite expressions and
+// per-field descending order are new, but a bare single field behaves
+// exactly as before.
+func ParseSortSpec(criteria string) (SortSpec, error) {
+	names := strings.Split(criteria, ",")
+	fields := make([]SortField, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		desc := strings.HasPrefix(name, "-")
+		if desc {
+			name = name[1:]
+		}
+		if _, ok := criteriaOfSort[name]; !ok {
+			return SortSpec{}, ErrCriteria
+		}
+		fields = append(fields, SortField{Name: name, Desc: desc})
+	}
+	return SortSpec{Fields: fields, Max: numOfReturnTrains}, nil
+}
+
+// sortTrainsBySpec stably sorts trains by fields, later fields taking
+// precedence. Sorting least-significant field first and letting
+// sort.SliceStable preserve prior ordering on ties is what makes the
+// composite order work.
+func sortTrainsBySpec(trains Trains, fields []SortField) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		sortByField(trains, fields[i])
+	}
+}
+
+func sortByField(trains Trains, field SortField) {
+	sort.SliceStable(trains, func(i, j int) bool {
+		cmp := compareField(trains[i], trains[j], field.Name)
+		if field.Desc {
+			cmp = -cmp
+		}
+		return cmp < 0
+	})
+}
+
+// compareField returns -1, 0 or 1 comparing a and b on the named field.
+func compareField(a, b Train, name string) int {
+	switch name {
+	case priceCriteria:
+		switch {
+		case a.Price < b.Price:
+			return -1
+		case a.Price > b.Price:
+			return 1
+		default:
+			return 0
+		}
+	case departureTimeCriteria:
+		return compareTime(a.DepartureTime, b.DepartureTime)
+	case arrivalTimeCriteria:
+		return compareTime(a.ArrivalTime, b.ArrivalTime)
+	default:
+		return 0
+	}
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
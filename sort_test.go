@@ -0,0 +1,74 @@
+package trainfinder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSortSpec(t *testing.T) {
+	assert := assert.New(t)
+
+	spec, err := ParseSortSpec("price")
+	assert.NoError(err)
+	assert.Equal([]SortField{{Name: "price"}}, spec.Fields)
+	assert.Equal(numOfReturnTrains, spec.Max)
+
+	spec, err = ParseSortSpec("price,departure-time")
+	assert.NoError(err)
+	assert.Equal([]SortField{{Name: "price"}, {Name: "departure-time"}}, spec.Fields)
+
+	_, err = ParseSortSpec("price,awef")
+	assert.ErrorIs(err, ErrCriteria)
+
+	spec, err = ParseSortSpec("price,-departure-time")
+	assert.NoError(err)
+	assert.Equal([]SortField{{Name: "price"}, {Name: "departure-time", Desc: true}}, spec.Fields)
+}
+
+func TestFindTrainsWithSpecCompositeOrderAndPaging(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 100, DepartureTime: time.Date(0, time.January, 1, 9, 0, 0, 0, time.UTC)},
+		{TrainID: 2, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 100, DepartureTime: time.Date(0, time.January, 1, 8, 0, 0, 0, time.UTC)},
+		{TrainID: 3, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 50, DepartureTime: time.Date(0, time.January, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	repo := NewMemoryRepository(data)
+
+	got, err := FindTrainsWithSpec(repo, "1902", "1929", SortSpec{
+		Fields: []SortField{{Name: "price"}, {Name: "departure-time"}},
+		Max:    10,
+	})
+	assert.NoError(err)
+	assert.Equal(Trains{data[2], data[1], data[0]}, got)
+
+	got, err = FindTrainsWithSpec(repo, "1902", "1929", SortSpec{
+		Fields: []SortField{{Name: "price", Desc: true}},
+		Max:    1,
+		Offset: 1,
+	})
+	assert.NoError(err)
+	assert.Equal(Trains{data[1]}, got)
+}
+
+func TestFindTrainsWithSpecMixedFieldDirections(t *testing.T) {
+	assert := assert.New(t)
+
+	data := Trains{
+		{TrainID: 1, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 100, DepartureTime: time.Date(0, time.January, 1, 9, 0, 0, 0, time.UTC)},
+		{TrainID: 2, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 100, DepartureTime: time.Date(0, time.January, 1, 8, 0, 0, 0, time.UTC)},
+		{TrainID: 3, DepartureStationID: 1902, ArrivalStationID: 1929, Price: 50, DepartureTime: time.Date(0, time.January, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	repo := NewMemoryRepository(data)
+
+	spec, err := ParseSortSpec("price,-departure-time")
+	assert.NoError(err)
+	spec.Max = 10
+
+	// price ascending, departure-time descending within equal price.
+	got, err := FindTrainsWithSpec(repo, "1902", "1929", spec)
+	assert.NoError(err)
+	assert.Equal(Trains{data[2], data[0], data[1]}, got)
+}
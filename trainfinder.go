@@ -0,0 +1,205 @@
+package trainfinder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dataPath              string = "data.json"
+	layout                string = "15:04:05"
+	priceCriteria         string = "price"
+	arrivalTimeCriteria   string = "arrival-time"
+	departureTimeCriteria string = "departure-time"
+	numOfReturnTrains     int    = 3
+	minIDNumber           int    = 1
+	minLen                int    = 1
+)
+
+var criteriaOfSort = map[string]string{
+	"price":          "price",
+	"arrival-time":   "arrival-time",
+	"departure-time": "departure-time",
+}
+
+type Trains []Train
+
+type Train struct {
+	TrainID            int
+	DepartureStationID int
+	ArrivalStationID   int
+	Price              float32
+	ArrivalTime        time.Time
+	DepartureTime      time.Time
+}
+
+func (t *Train) UnmarshalJSON(b []byte) error {
+
+	var alias struct {
+		TrainID            int        `json:"trainId"`
+		DepartureStationID int        `json:"departureStationId"`
+		ArrivalStationID   int        `json:"arrivalStationId"`
+		Price              float32    `json:"price"`
+		ArrivalTime        CustomTime `json:"arrivalTime"`
+		DepartureTime      CustomTime `json:"departureTime"`
+	}
+
+	err := json.Unmarshal(b, &alias)
+	if err != nil {
+		return err
+	}
+
+	t.TrainID = alias.TrainID
+	t.DepartureStationID = alias.DepartureStationID
+	t.ArrivalStationID = alias.ArrivalStationID
+	t.Price = alias.Price
+	t.ArrivalTime = time.Time(alias.ArrivalTime)
+	t.DepartureTime = time.Time(alias.DepartureTime)
+	return nil
+}
+
+func (t *Train) printTrain() {
+	fmt.Printf("%+v\n", t)
+}
+
+type CustomTime time.Time
+
+// ParseClockTime parses a bare "15:04:05" time of day using the same layout
+// CustomTime does, so other data sources (e.g. the gtfs package) produce
+// Train times consistent with the ones read from data.json.
+func ParseClockTime(s string) (time.Time, error) {
+	return time.Parse(layout, s)
+}
+
+func (c *CustomTime) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `\"`)
+	if s == "null" || s == "" {
+		return nil
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return err
+	}
+	*c = CustomTime(t)
+	return nil
+}
+
+func PrintFindingResult(t Trains) {
+	if len(t) >= minLen {
+		for _, v := range t {
+			v.printTrain()
+		}
+	} else {
+		fmt.Println("Can't find trains on request. Please, try again.")
+	}
+
+}
+
+// parseStationID validates a raw station ID string, returning emptyErr if it's
+// blank and badErr if it isn't a positive integer.
+func parseStationID(raw string, emptyErr, badErr error) (int, error) {
+	if len(raw) < minLen {
+		return 0, emptyErr
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil || id < minIDNumber {
+		return 0, badErr
+	}
+	return id, nil
+}
+
+// applySpec sorts trains by spec.Fields and applies spec.Offset/spec.Max. It
+// returns nil for an empty input, matching the "no trains found" result
+// callers expect.
+func applySpec(trains Trains, spec SortSpec) Trains {
+	if len(trains) < minLen {
+		return nil
+	}
+	sortTrainsBySpec(trains, spec.Fields)
+
+	offset := spec.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(trains) {
+		return nil
+	}
+	trains = trains[offset:]
+
+	max := spec.Max
+	if max <= 0 {
+		max = numOfReturnTrains
+	}
+	if len(trains) > max {
+		trains = trains[:max]
+	}
+	return trains
+}
+
+// FindTrains looks up trains in the default data.json-backed repository.
+// It exists for backward compatibility with the single-shot CLI; callers
+// that can hold on to a repository (servers, tests) should use
+// FindTrainsFrom instead so the dataset isn't reloaded on every call.
+func FindTrains(departureStation, arrivalStation, criteria string) (Trains, error) {
+	if _, _, err := validateStations(departureStation, arrivalStation); err != nil {
+		return nil, err
+	}
+	if _, err := ParseSortSpec(criteria); err != nil {
+		return nil, err
+	}
+
+	repo, err := NewJSONFileRepository(dataPath)
+	if err != nil {
+		return nil, err
+	}
+	return FindTrainsFrom(repo, departureStation, arrivalStation, criteria)
+}
+
+// FindTrainsFrom validates the query and looks up matching trains through
+// repo. criteria is a bare field name ("price") or a composite, comma
+// separated expression ("price,departure-time"); both sort ascending with
+// the default limit. Callers that need descending order, a custom limit or
+// pagination should build a SortSpec and call FindTrainsWithSpec instead.
+func FindTrainsFrom(repo TrainRepository, departureStation, arrivalStation, criteria string) (Trains, error) {
+	spec, err := ParseSortSpec(criteria)
+	if err != nil {
+		return nil, err
+	}
+	return FindTrainsWithSpec(repo, departureStation, arrivalStation, spec)
+}
+
+// FindTrainsWithSpec validates the query and looks up matching trains
+// through repo, sorting and paginating them according to spec.
+func FindTrainsWithSpec(repo TrainRepository, departureStation, arrivalStation string, spec SortSpec) (Trains, error) {
+	departureStationID, arrivalStationID, err := validateStations(departureStation, arrivalStation)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range spec.Fields {
+		if _, ok := criteriaOfSort[field.Name]; !ok {
+			return nil, ErrCriteria
+		}
+	}
+
+	trains, err := repo.FindByRoute(departureStationID, arrivalStationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return applySpec(trains, spec), nil
+}
+
+// validateStations parses and validates the departure/arrival station IDs,
+// independent of sorting.
+func validateStations(departureStation, arrivalStation string) (departureStationID, arrivalStationID int, err error) {
+	departureStationID, err = parseStationID(departureStation, ErrEmptyDepStation, ErrBadDepStation)
+	if err != nil {
+		return
+	}
+	arrivalStationID, err = parseStationID(arrivalStation, ErrEmptyArrStation, ErrBadArrStation)
+	return
+}
@@ -1,4 +1,4 @@
-package main
+package trainfinder
 
 import (
 	"errors"
@@ -149,4 +149,4 @@ func TestFindTrainsErrors(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}